@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type bigQueryIntrospector struct{}
+
+// Describe reads the schema and metadata of a BigQuery table addressed as
+// "bigquery://project.dataset.table", using ambient GCP SDK credentials.
+func (bigQueryIntrospector) Describe(ctx context.Context, uri string) (*Model, *ServerInfo, error) {
+	project, dataset, table, err := parseBigQueryURI(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := bigquery.NewClient(ctx, project)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	metadata, err := client.Dataset(dataset).Table(table).Metadata(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	model := &Model{
+		Name:   table,
+		Fields: bigQueryFields(metadata.Schema),
+	}
+
+	server := &ServerInfo{
+		Type:     "bigquery",
+		Location: metadata.Location,
+		Format:   "bigquery",
+	}
+
+	return model, server, nil
+}
+
+func bigQueryFields(schema bigquery.Schema) []ModelField {
+	fields := make([]ModelField, 0, len(schema))
+
+	for _, field := range schema {
+		fields = append(fields, bigQueryField(field))
+	}
+
+	return fields
+}
+
+// bigQueryField translates a single FieldSchema, preserving RECORD nesting
+// via ModelField.Fields and, for repeated columns, the element type via
+// ModelField.Items rather than collapsing either case to a bare scalar.
+func bigQueryField(field *bigquery.FieldSchema) ModelField {
+	modelField := ModelField{
+		Name:        field.Name,
+		Description: field.Description,
+		Required:    field.Required,
+	}
+
+	item := bigQueryItemField(field)
+
+	if field.Repeated {
+		modelField.Type = "array"
+		modelField.Items = &item
+		return modelField
+	}
+
+	modelField.Type = item.Type
+	modelField.Fields = item.Fields
+
+	return modelField
+}
+
+// bigQueryItemField resolves field's element type, ignoring Repeated, so it
+// can be used both for a scalar column and as an array's item type.
+func bigQueryItemField(field *bigquery.FieldSchema) ModelField {
+	if field.Type == bigquery.RecordFieldType {
+		return ModelField{Type: "object", Fields: bigQueryFields(field.Schema)}
+	}
+
+	return ModelField{Type: bigQueryScalarType(field.Type)}
+}
+
+func bigQueryScalarType(fieldType bigquery.FieldType) string {
+	switch fieldType {
+	case bigquery.StringFieldType:
+		return "string"
+	case bigquery.IntegerFieldType:
+		return "integer"
+	case bigquery.FloatFieldType, bigquery.NumericFieldType, bigquery.BigNumericFieldType:
+		return "double"
+	case bigquery.BooleanFieldType:
+		return "boolean"
+	case bigquery.TimestampFieldType, bigquery.DateTimeFieldType:
+		return "timestamp"
+	case bigquery.DateFieldType:
+		return "date"
+	case bigquery.BytesFieldType:
+		return "binary"
+	default:
+		return "string"
+	}
+}
+
+func parseBigQueryURI(uri string) (project, dataset, table string, err error) {
+	ref := strings.TrimPrefix(uri, "bigquery://")
+	parts := strings.Split(ref, ".")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid bigquery uri %q, expected bigquery://project.dataset.table", uri)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
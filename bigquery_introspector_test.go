@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestParseBigQueryURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		wantProject string
+		wantDataset string
+		wantTable   string
+		wantErr     bool
+	}{
+		{
+			name:        "valid uri",
+			uri:         "bigquery://my-project.my_dataset.my_table",
+			wantProject: "my-project",
+			wantDataset: "my_dataset",
+			wantTable:   "my_table",
+		},
+		{
+			name:    "missing table",
+			uri:     "bigquery://my-project.my_dataset",
+			wantErr: true,
+		},
+		{
+			name:    "too many parts",
+			uri:     "bigquery://my-project.my_dataset.my_table.extra",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project, dataset, table, err := parseBigQueryURI(test.uri)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", test.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBigQueryURI: %v", err)
+			}
+			if project != test.wantProject || dataset != test.wantDataset || table != test.wantTable {
+				t.Fatalf("got (%q, %q, %q), want (%q, %q, %q)",
+					project, dataset, table, test.wantProject, test.wantDataset, test.wantTable)
+			}
+		})
+	}
+}
+
+func TestBigQueryScalarType(t *testing.T) {
+	tests := []struct {
+		fieldType bigquery.FieldType
+		want      string
+	}{
+		{bigquery.StringFieldType, "string"},
+		{bigquery.IntegerFieldType, "integer"},
+		{bigquery.FloatFieldType, "double"},
+		{bigquery.NumericFieldType, "double"},
+		{bigquery.BooleanFieldType, "boolean"},
+		{bigquery.TimestampFieldType, "timestamp"},
+		{bigquery.DateTimeFieldType, "timestamp"},
+		{bigquery.DateFieldType, "date"},
+		{bigquery.BytesFieldType, "binary"},
+		{bigquery.GeographyFieldType, "string"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.fieldType), func(t *testing.T) {
+			if got := bigQueryScalarType(test.fieldType); got != test.want {
+				t.Fatalf("bigQueryScalarType(%v) = %v, want %v", test.fieldType, got, test.want)
+			}
+		})
+	}
+}
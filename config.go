@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func configPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "datacontract", "config.yml"), nil
+}
+
+// loadConfig reads the global config file, returning an empty config if it
+// doesn't exist yet.
+func loadConfig() (map[string]any, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]any{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	config := map[string]any{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func saveConfig(config map[string]any) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ConfigGet returns the value at a dotted key path such as "defaults.info.owner".
+// A segment that itself contains dots, such as a schema version, can be
+// bracketed to keep it intact: "sources.schemas.[1.1.0].url_prefix".
+func ConfigGet(key string) (string, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := valueAtPath(config, splitConfigKey(key))
+	if !ok {
+		return "", fmt.Errorf("key %v not set", key)
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
+
+// ConfigSet writes value at a dotted key path, creating intermediate maps as
+// needed. A segment that itself contains dots, such as a schema version, can
+// be bracketed to keep it intact: "sources.schemas.[1.1.0].url_prefix".
+func ConfigSet(key, value string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	setValueAtPath(config, splitConfigKey(key), value)
+
+	return saveConfig(config)
+}
+
+// splitConfigKey splits a dotted key path into segments, treating dots
+// inside a bracketed segment ("[1.1.0]") as literal rather than as
+// separators, so a segment that isn't itself dot-free (e.g. a
+// dataContractSpecification version) can still be addressed from the
+// command line.
+func splitConfigKey(key string) []string {
+	var segments []string
+	var current strings.Builder
+	bracketed := false
+
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case c == '[' && !bracketed:
+			bracketed = true
+		case c == ']' && bracketed:
+			bracketed = false
+		case c == '.' && !bracketed:
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	segments = append(segments, current.String())
+
+	return segments
+}
+
+// ConfigList returns every leaf value in the config, flattened to dotted
+// keys in the same bracket-escaped form ConfigGet/ConfigSet accept, so a
+// key round-trips even when one of its segments (e.g. a schema version)
+// itself contains dots.
+func ConfigList() (map[string]string, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	flattened := make(map[string]string)
+	flattenConfigValues("", config, flattened)
+
+	return flattened, nil
+}
+
+// flattenConfigValues mirrors flattenValues, but joins segments using
+// appendConfigKeySegment instead of a bare ".", so the resulting keys are
+// accepted back by splitConfigKey.
+func flattenConfigValues(prefix string, in map[string]any, out map[string]string) {
+	for key, value := range in {
+		identifier := appendConfigKeySegment(prefix, key)
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenConfigValues(identifier, nested, out)
+			continue
+		}
+
+		out[identifier] = fmt.Sprintf("%v", value)
+	}
+}
+
+// appendConfigKeySegment appends segment to prefix, bracketing it first if
+// it contains a dot that splitConfigKey would otherwise treat as a separator.
+func appendConfigKeySegment(prefix, segment string) string {
+	if strings.Contains(segment, ".") {
+		segment = "[" + segment + "]"
+	}
+	if prefix == "" {
+		return segment
+	}
+
+	return prefix + "." + segment
+}
+
+func valueAtPath(node any, path []string) (any, bool) {
+	m, ok := node.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := m[path[0]]
+	if !ok {
+		return nil, false
+	}
+	if len(path) == 1 {
+		return value, true
+	}
+
+	return valueAtPath(value, path[1:])
+}
+
+func setValueAtPath(m map[string]any, path []string, value string) {
+	if len(path) == 1 {
+		m[path[0]] = value
+		return
+	}
+
+	child, ok := m[path[0]].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		m[path[0]] = child
+	}
+
+	setValueAtPath(child, path[1:], value)
+}
+
+// configDefault looks up defaults.<identifier> set via
+// `datacontract config set defaults.info.owner team-x`.
+func configDefault(identifier string) (string, bool) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := valueAtPath(config, append([]string{"defaults"}, strings.Split(identifier, ".")...))
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf("%v", value), true
+}
+
+// schemaURLPrefix returns sources.schemas.<name>.url_prefix if configured,
+// e.g. via `datacontract config set sources.schemas.[1.1.0].url_prefix ...`.
+func schemaURLPrefix(name string) (string, bool) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := valueAtPath(config, []string{"sources", "schemas", name, "url_prefix"})
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf("%v", value), true
+}
+
+// configTemplate returns the starter YAML for a named template, e.g. "kafka-topic".
+func configTemplate(name string) (string, bool) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := valueAtPath(config, []string{"templates", name})
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf("%v", value), true
+}
+
+// modelFromTemplate parses a configured starter YAML skeleton into the same
+// shape as the other Init generators, so it can be merged via filterGenerated.
+func modelFromTemplate(name string) (map[string]any, error) {
+	raw, ok := configTemplate(name)
+	if !ok {
+		return nil, fmt.Errorf("template %v not found in config", name)
+	}
+
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
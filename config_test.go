@@ -0,0 +1,260 @@
+package main
+
+import "testing"
+
+func TestValueAtPath(t *testing.T) {
+	node := map[string]any{
+		"info": map[string]any{
+			"owner": "team-x",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		path   []string
+		want   any
+		wantOk bool
+	}{
+		{name: "nested value", path: []string{"info", "owner"}, want: "team-x", wantOk: true},
+		{name: "missing leaf", path: []string{"info", "title"}, wantOk: false},
+		{name: "missing branch", path: []string{"sources"}, wantOk: false},
+		{name: "path through a non-map leaf", path: []string{"info", "owner", "extra"}, wantOk: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := valueAtPath(node, test.path)
+			if ok != test.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, test.wantOk)
+			}
+			if ok && got != test.want {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetValueAtPath(t *testing.T) {
+	m := map[string]any{}
+
+	setValueAtPath(m, []string{"info", "owner"}, "team-x")
+
+	info, ok := m["info"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected setValueAtPath to create intermediate maps, got %+v", m)
+	}
+	if info["owner"] != "team-x" {
+		t.Fatalf("info.owner = %v, want team-x", info["owner"])
+	}
+
+	setValueAtPath(m, []string{"info", "title"}, "Order Events")
+	if info["title"] != "Order Events" {
+		t.Fatalf("expected setting a sibling key to preserve info.owner, got %+v", info)
+	}
+}
+
+func TestConfigGetSetRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := ConfigSet("defaults.info.owner", "team-x"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	got, err := ConfigGet("defaults.info.owner")
+	if err != nil {
+		t.Fatalf("ConfigGet: %v", err)
+	}
+	if got != "team-x" {
+		t.Fatalf("ConfigGet = %q, want team-x", got)
+	}
+
+	if _, err := ConfigGet("defaults.info.title"); err == nil {
+		t.Fatalf("expected an error for an unset key")
+	}
+}
+
+func TestConfigList(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := ConfigSet("defaults.info.owner", "team-x"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+	if err := ConfigSet("defaults.info.title", "Order Events"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	list, err := ConfigList()
+	if err != nil {
+		t.Fatalf("ConfigList: %v", err)
+	}
+
+	if list["defaults.info.owner"] != "team-x" {
+		t.Fatalf("defaults.info.owner = %q, want team-x", list["defaults.info.owner"])
+	}
+	if list["defaults.info.title"] != "Order Events" {
+		t.Fatalf("defaults.info.title = %q, want Order Events", list["defaults.info.title"])
+	}
+}
+
+func TestConfigListBracketsDottedSegments(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := ConfigSet("sources.schemas.[1.1.0].url_prefix", "https://example.com/schemas"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	list, err := ConfigList()
+	if err != nil {
+		t.Fatalf("ConfigList: %v", err)
+	}
+
+	const wantKey = "sources.schemas.[1.1.0].url_prefix"
+	value, ok := list[wantKey]
+	if !ok {
+		t.Fatalf("ConfigList() = %v, want a %q key", list, wantKey)
+	}
+	if value != "https://example.com/schemas" {
+		t.Fatalf("list[%q] = %q, want https://example.com/schemas", wantKey, value)
+	}
+
+	got, err := ConfigGet(wantKey)
+	if err != nil {
+		t.Fatalf("ConfigGet(%q): %v", wantKey, err)
+	}
+	if got != "https://example.com/schemas" {
+		t.Fatalf("ConfigGet(%q) = %q, want https://example.com/schemas", wantKey, got)
+	}
+}
+
+func TestConfigDefault(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, ok := configDefault("info.owner"); ok {
+		t.Fatalf("expected no default before any config is set")
+	}
+
+	if err := ConfigSet("defaults.info.owner", "team-x"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	value, ok := configDefault("info.owner")
+	if !ok || value != "team-x" {
+		t.Fatalf("configDefault(info.owner) = (%q, %v), want (team-x, true)", value, ok)
+	}
+}
+
+func TestSchemaURLPrefix(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, ok := schemaURLPrefix("1.1.0"); ok {
+		t.Fatalf("expected no url prefix before any config is set")
+	}
+
+	// The version itself contains dots, so it's written as a single path
+	// segment directly rather than through ConfigSet's dotted-key split.
+	config := map[string]any{
+		"sources": map[string]any{
+			"schemas": map[string]any{
+				"1.1.0": map[string]any{
+					"url_prefix": "https://example.com/schemas",
+				},
+			},
+		},
+	}
+	if err := saveConfig(config); err != nil {
+		t.Fatalf("saveConfig: %v", err)
+	}
+
+	value, ok := schemaURLPrefix("1.1.0")
+	if !ok || value != "https://example.com/schemas" {
+		t.Fatalf("schemaURLPrefix(1.1.0) = (%q, %v), want (https://example.com/schemas, true)", value, ok)
+	}
+}
+
+func TestSplitConfigKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want []string
+	}{
+		{name: "plain dotted key", key: "defaults.info.owner", want: []string{"defaults", "info", "owner"}},
+		{
+			name: "bracketed segment keeps its dots literal",
+			key:  "sources.schemas.[1.1.0].url_prefix",
+			want: []string{"sources", "schemas", "1.1.0", "url_prefix"},
+		},
+		{name: "whole key bracketed", key: "[1.1.0]", want: []string{"1.1.0"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := splitConfigKey(test.key)
+			if len(got) != len(test.want) {
+				t.Fatalf("splitConfigKey(%q) = %v, want %v", test.key, got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("splitConfigKey(%q) = %v, want %v", test.key, got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigSetGetWithBracketedVersionSegment(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := ConfigSet("sources.schemas.[1.1.0].url_prefix", "https://example.com/schemas"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	got, err := ConfigGet("sources.schemas.[1.1.0].url_prefix")
+	if err != nil {
+		t.Fatalf("ConfigGet: %v", err)
+	}
+	if got != "https://example.com/schemas" {
+		t.Fatalf("ConfigGet = %q, want https://example.com/schemas", got)
+	}
+
+	value, ok := schemaURLPrefix("1.1.0")
+	if !ok || value != "https://example.com/schemas" {
+		t.Fatalf("schemaURLPrefix(1.1.0) = (%q, %v), want (https://example.com/schemas, true)", value, ok)
+	}
+}
+
+func TestConfigTemplate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, ok := configTemplate("kafka-topic"); ok {
+		t.Fatalf("expected no template before any config is set")
+	}
+
+	if err := ConfigSet("templates.kafka-topic", "dataContractSpecification: 1.1.0"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	value, ok := configTemplate("kafka-topic")
+	if !ok || value != "dataContractSpecification: 1.1.0" {
+		t.Fatalf("configTemplate(kafka-topic) = (%q, %v), want (dataContractSpecification: 1.1.0, true)", value, ok)
+	}
+}
+
+func TestModelFromTemplate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, err := modelFromTemplate("kafka-topic"); err == nil {
+		t.Fatalf("expected an error for an unconfigured template")
+	}
+
+	if err := ConfigSet("templates.kafka-topic", "dataContractSpecification: 1.1.0\nid: topic"); err != nil {
+		t.Fatalf("ConfigSet: %v", err)
+	}
+
+	parsed, err := modelFromTemplate("kafka-topic")
+	if err != nil {
+		t.Fatalf("modelFromTemplate: %v", err)
+	}
+	if parsed["id"] != "topic" {
+		t.Fatalf("parsed[id] = %v, want topic", parsed["id"])
+	}
+}
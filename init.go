@@ -2,9 +2,13 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -15,7 +19,41 @@ type suggestion struct {
 	Description string
 }
 
-func Init(version, path string) error {
+// InitOptions controls how Init sources its identifier values before
+// falling back to interactive prompting.
+type InitOptions struct {
+	// ValuesFile is a path to a YAML or JSON file of pre-populated
+	// identifiers, e.g. `info: {owner: team-x}`.
+	ValuesFile string
+	// SetValues are repeated `--set info.owner=team-x` overrides.
+	SetValues []string
+	// NonInteractive disables the prompt loop; any required field still
+	// missing once all sources are merged causes Init to fail fast.
+	NonInteractive bool
+	// FromOpenAPI is a path to an OpenAPI 3 or Swagger 2 document to
+	// bootstrap models from, paired with SchemaRef.
+	FromOpenAPI string
+	// SchemaRef selects the schema to translate, e.g.
+	// "#/components/schemas/Order".
+	SchemaRef string
+	// FromProto is a path to a .proto file to bootstrap a model from,
+	// paired with Message.
+	FromProto string
+	// Message selects the message to translate, e.g. "pkg.Order".
+	Message string
+	// FromSource is a live resource URI to introspect, e.g.
+	// "bigquery://project.dataset.table" or "postgres://host/db/schema.table".
+	FromSource string
+	// Template selects a starter YAML skeleton from the global config, e.g.
+	// "kafka-topic".
+	Template string
+	// Output is the emitted file format, "yaml" (default) or "json".
+	Output string
+	// Stdout writes the contract to standard output instead of a file.
+	Stdout bool
+}
+
+func Init(version, path string, opts InitOptions) error {
 	schema, err := schema(version)
 
 	if err != nil {
@@ -25,20 +63,155 @@ func Init(version, path string) error {
 	values := make(map[string]string)
 	values["dataContractSpecification"] = version
 
-	promptRequiredFields(schema, values)
+	if err := mergeValueSources(schema, values, opts); err != nil {
+		return err
+	}
+
+	var generated map[string]any
+	switch {
+	case opts.FromOpenAPI != "":
+		generated, err = modelsFromOpenAPI(opts.FromOpenAPI, opts.SchemaRef)
+	case opts.FromProto != "":
+		generated, err = modelsFromProto(opts.FromProto, opts.Message)
+	case opts.FromSource != "":
+		generated, err = modelsFromSource(context.Background(), opts.FromSource)
+	case opts.Template != "":
+		generated, err = modelFromTemplate(opts.Template)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.NonInteractive {
+		if missing := missingRequiredFields(schema, values); len(missing) > 0 {
+			return fmt.Errorf("non-interactive: missing required fields: %v", strings.Join(missing, ", "))
+		}
+	} else {
+		promptRequiredFields(schema, values)
+	}
 
 	valuesInSchema := inSchema(values, schema)
+	for fieldName, value := range filterGenerated(schema, generated) {
+		valuesInSchema = valuesInSchema.set(fieldName, value)
+	}
+
+	return createDataContractSpecificationFile(valuesInSchema, path, opts.Output, opts.Stdout)
+}
+
+// filterGenerated keeps only the top-level fields that the loaded schema
+// actually declares, so generated content still validates against the
+// schema version in use.
+func filterGenerated(schema Schema, generated map[string]any) map[string]any {
+	allowed := make(map[string]bool, len(schema))
+	for _, field := range schema {
+		allowed[field.FieldName] = true
+	}
+
+	filtered := make(map[string]any, len(generated))
+	for fieldName, value := range generated {
+		if allowed[fieldName] {
+			filtered[fieldName] = value
+		}
+	}
+
+	return filtered
+}
+
+// mergeValueSources populates values from, in increasing order of
+// precedence: the values file, environment variables, then --set flags.
+func mergeValueSources(schema Schema, values map[string]string, opts InitOptions) error {
+	if opts.ValuesFile != "" {
+		fileValues, err := readValuesFile(opts.ValuesFile)
+		if err != nil {
+			return fmt.Errorf("reading values file %v: %w", opts.ValuesFile, err)
+		}
+		for identifier, value := range fileValues {
+			values[identifier] = value
+		}
+	}
+
+	for _, field := range schema.Flattened() {
+		if value, ok := os.LookupEnv(envVarName(field.Identifier)); ok {
+			values[field.Identifier] = value
+		}
+	}
+
+	for _, set := range opts.SetValues {
+		identifier, value, ok := strings.Cut(set, "=")
+		if !ok {
+			return fmt.Errorf("invalid --set value %q, expected identifier=value", set)
+		}
+		values[identifier] = value
+	}
+
+	return nil
+}
+
+// envVarName maps a dotted schema identifier like "info.owner" to the
+// environment variable DATACONTRACT_INFO_OWNER.
+func envVarName(identifier string) string {
+	return "DATACONTRACT_" + strings.ToUpper(strings.ReplaceAll(identifier, ".", "_"))
+}
+
+func readValuesFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flattenValues("", raw, values)
+
+	return values, nil
+}
+
+// flattenValues turns a nested map read from a values file into dotted
+// identifiers matching schema.Flattened(), e.g. {info: {owner: x}} -> "info.owner".
+func flattenValues(prefix string, in map[string]any, out map[string]string) {
+	for key, value := range in {
+		identifier := key
+		if prefix != "" {
+			identifier = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenValues(identifier, nested, out)
+			continue
+		}
+
+		if value == nil {
+			out[identifier] = ""
+			continue
+		}
+
+		out[identifier] = fmt.Sprintf("%v", value)
+	}
+}
+
+func missingRequiredFields(schema Schema, values map[string]string) []string {
+	var missing []string
+
+	for _, field := range schema.Flattened() {
+		if field.Required && values[field.Identifier] == "" {
+			missing = append(missing, field.Identifier)
+		}
+	}
 
-	return createDataContractSpecificationFile(valuesInSchema, path)
+	return missing
 }
 
 func schema(version string) (Schema, error) {
-	var err error
+	file, err := schemaBytes(version)
+	if err != nil {
+		return nil, err
+	}
 
-	schemaFileName := fmt.Sprintf("schema-%v.json", version)
-	file, err := os.ReadFile(schemaFileName)
 	schema, err := GenerateSchema(file)
-
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +221,33 @@ func schema(version string) (Schema, error) {
 	return *schema, err
 }
 
+// schemaBytes reads schema-<version>.json from the working directory,
+// unless a registry is configured via `sources.schemas.<version>.url_prefix`,
+// in which case it's fetched from there instead.
+func schemaBytes(version string) ([]byte, error) {
+	schemaFileName := fmt.Sprintf("schema-%v.json", version)
+
+	if urlPrefix, ok := schemaURLPrefix(version); ok {
+		return fetchSchema(urlPrefix, schemaFileName)
+	}
+
+	return os.ReadFile(schemaFileName)
+}
+
+func fetchSchema(urlPrefix, schemaFileName string) ([]byte, error) {
+	resp, err := http.Get(strings.TrimSuffix(urlPrefix, "/") + "/" + schemaFileName)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching schema from %v: %v", urlPrefix, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
 func promptRequiredFields(schema Schema, values map[string]string) {
 	for _, field := range schema.Flattened() {
 		if field.Required && values[field.Identifier] == "" {
@@ -100,9 +300,14 @@ func fieldSuggestionByFieldType(field SchemaField) *suggestion {
 }
 
 func fieldSuggestionByDefault(field SchemaField) *suggestion {
+	if value, ok := configDefault(field.Identifier); ok {
+		return &suggestion{value, "from config"}
+	}
+
 	if field.Default != "" {
 		return &suggestion{field.Default, "default"}
 	}
+
 	return nil
 }
 
@@ -136,44 +341,53 @@ func readUserInput() (string, error) {
 	return strings.TrimSuffix(input, "\n"), nil
 }
 
-func inSchema(values map[string]string, schema Schema) map[string]any {
-	yamlMap := make(map[string]any)
+// inSchema builds the contract document in the schema's own field order,
+// since Go map iteration and yaml.v3 both randomize/alphabetize key order
+// otherwise.
+func inSchema(values map[string]string, schema Schema) orderedMap {
+	ordered := make(orderedMap, 0, len(schema))
 
 	for _, schemaField := range schema {
 		if value, ok := values[schemaField.Identifier]; ok {
-			yamlMap[schemaField.FieldName] = value
+			ordered = append(ordered, orderedField{schemaField.FieldName, value})
 			continue
 		}
 
 		switch schemaField.Type {
 		case SchemaFieldTypeObject:
-			yamlMap[schemaField.FieldName] = inSchema(values, *schemaField.ObjectSchema)
+			ordered = append(ordered, orderedField{schemaField.FieldName, inSchema(values, *schemaField.ObjectSchema)})
 		case SchemaFieldTypeArray:
-			yamlMap[schemaField.FieldName] = []any{}
+			ordered = append(ordered, orderedField{schemaField.FieldName, []any{}})
 		case SchemaFieldTypeString, SchemaFieldTypeDate, SchemaFieldTypeDuration:
-			yamlMap[schemaField.FieldName] = ""
+			ordered = append(ordered, orderedField{schemaField.FieldName, ""})
 		default:
-			yamlMap[schemaField.FieldName] = nil
+			ordered = append(ordered, orderedField{schemaField.FieldName, nil})
 		}
 	}
 
-	return yamlMap
+	return ordered
 }
 
-func createDataContractSpecificationFile(values map[string]any, path string) error {
+func createDataContractSpecificationFile(values orderedMap, path, output string, stdout bool) error {
+	result, err := marshalContract(values, output)
+	if err != nil {
+		return err
+	}
+
+	if stdout {
+		fmt.Print(result)
+		return nil
+	}
+
 	if path == "" {
-		path = "datacontract.yml"
+		path = defaultContractPath(output)
 	}
 
 	file, err := createFile(path)
-	defer file.Close()
-
 	if err != nil {
 		return err
 	}
-
-	yamlBytes, _ := yaml.Marshal(values)
-	result := string(yamlBytes)
+	defer file.Close()
 
 	fmt.Fprint(file, result)
 
@@ -183,6 +397,31 @@ func createDataContractSpecificationFile(values map[string]any, path string) err
 	return nil
 }
 
+func marshalContract(values orderedMap, output string) (string, error) {
+	if output == "json" {
+		jsonBytes, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	}
+
+	yamlBytes, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return string(yamlBytes), nil
+}
+
+func defaultContractPath(output string) string {
+	if output == "json" {
+		return "datacontract.json"
+	}
+
+	return "datacontract.yml"
+}
+
 func createFile(path string) (*os.File, error) {
 	file, error := os.Create(path)
 
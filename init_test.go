@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeValueSourcesPrecedence(t *testing.T) {
+	schema := Schema{{Identifier: "info.owner", FieldName: "owner"}}
+
+	t.Run("values file alone", func(t *testing.T) {
+		valuesFile := writeValuesFile(t, "info:\n  owner: from-file\n")
+
+		values := map[string]string{}
+		if err := mergeValueSources(schema, values, InitOptions{ValuesFile: valuesFile}); err != nil {
+			t.Fatalf("mergeValueSources: %v", err)
+		}
+
+		if values["info.owner"] != "from-file" {
+			t.Fatalf("info.owner = %q, want from-file", values["info.owner"])
+		}
+	})
+
+	t.Run("environment overrides the values file", func(t *testing.T) {
+		valuesFile := writeValuesFile(t, "info:\n  owner: from-file\n")
+		t.Setenv(envVarName("info.owner"), "from-env")
+
+		values := map[string]string{}
+		if err := mergeValueSources(schema, values, InitOptions{ValuesFile: valuesFile}); err != nil {
+			t.Fatalf("mergeValueSources: %v", err)
+		}
+
+		if values["info.owner"] != "from-env" {
+			t.Fatalf("info.owner = %q, want from-env", values["info.owner"])
+		}
+	})
+
+	t.Run("--set overrides the values file and environment", func(t *testing.T) {
+		valuesFile := writeValuesFile(t, "info:\n  owner: from-file\n")
+		t.Setenv(envVarName("info.owner"), "from-env")
+
+		values := map[string]string{}
+		opts := InitOptions{ValuesFile: valuesFile, SetValues: []string{"info.owner=from-set"}}
+		if err := mergeValueSources(schema, values, opts); err != nil {
+			t.Fatalf("mergeValueSources: %v", err)
+		}
+
+		if values["info.owner"] != "from-set" {
+			t.Fatalf("info.owner = %q, want from-set", values["info.owner"])
+		}
+	})
+
+	t.Run("invalid --set value is rejected", func(t *testing.T) {
+		values := map[string]string{}
+		opts := InitOptions{SetValues: []string{"info.owner"}}
+		if err := mergeValueSources(schema, values, opts); err == nil {
+			t.Fatalf("expected an error for a --set value without '='")
+		}
+	})
+
+	t.Run("a blank value in the values file is treated as missing, not the literal <nil>", func(t *testing.T) {
+		valuesFile := writeValuesFile(t, "info:\n  owner:\n")
+
+		values := map[string]string{}
+		if err := mergeValueSources(schema, values, InitOptions{ValuesFile: valuesFile}); err != nil {
+			t.Fatalf("mergeValueSources: %v", err)
+		}
+
+		if values["info.owner"] != "" {
+			t.Fatalf("info.owner = %q, want empty string", values["info.owner"])
+		}
+
+		requiredSchema := Schema{{Identifier: "info.owner", FieldName: "owner", Required: true}}
+		if missing := missingRequiredFields(requiredSchema, values); len(missing) != 1 {
+			t.Fatalf("missingRequiredFields = %v, want [info.owner]", missing)
+		}
+	})
+}
+
+func writeValuesFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing values file: %v", err)
+	}
+
+	return path
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Model mirrors the subset of datacontract model/field shape an
+// Introspector can derive from a live data source.
+type Model struct {
+	Name   string
+	Fields []ModelField
+}
+
+type ModelField struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	// Fields holds nested columns for a "object"-typed field (e.g. a
+	// BigQuery RECORD).
+	Fields []ModelField
+	// Items holds the element type for an "array"-typed field.
+	Items *ModelField
+}
+
+// ServerInfo captures the server-level metadata (location, format,
+// endpoint) an Introspector discovers alongside a model's fields.
+type ServerInfo struct {
+	Type     string
+	Location string
+	Format   string
+	Endpoint string
+}
+
+// Introspector populates a datacontract model from a live data source
+// addressed by uri, e.g. "bigquery://project.dataset.table".
+type Introspector interface {
+	Describe(ctx context.Context, uri string) (*Model, *ServerInfo, error)
+}
+
+var introspectors = map[string]Introspector{
+	"bigquery": bigQueryIntrospector{},
+	"postgres": postgresIntrospector{},
+	"s3":       s3Introspector{},
+}
+
+// modelsFromSource dispatches uri to the Introspector registered for its
+// scheme and translates the result into datacontract "models"/"servers"
+// maps ready to merge into the values in use.
+func modelsFromSource(ctx context.Context, uri string) (map[string]any, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	introspector, ok := introspectors[parsed.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported source scheme %q", parsed.Scheme)
+	}
+
+	model, server, err := introspector.Describe(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := modelKey(model.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := map[string]any{
+		"models": map[string]any{
+			key: map[string]any{
+				"type":   "table",
+				"fields": modelFieldsToYAML(model.Fields),
+			},
+		},
+	}
+
+	if server != nil {
+		generated["servers"] = map[string]any{
+			parsed.Scheme: serverInfoToYAML(server),
+		}
+	}
+
+	return generated, nil
+}
+
+func modelFieldsToYAML(fields []ModelField) map[string]any {
+	yamlFields := make(map[string]any, len(fields))
+
+	for _, field := range fields {
+		yamlFields[field.Name] = modelFieldToYAML(field)
+	}
+
+	return yamlFields
+}
+
+func modelFieldToYAML(field ModelField) map[string]any {
+	fieldMap := map[string]any{"type": field.Type}
+
+	if field.Description != "" {
+		fieldMap["description"] = field.Description
+	}
+	if field.Required {
+		fieldMap["required"] = true
+	}
+	if len(field.Fields) > 0 {
+		fieldMap["fields"] = modelFieldsToYAML(field.Fields)
+	}
+	if field.Items != nil {
+		fieldMap["items"] = modelFieldToYAML(*field.Items)
+	}
+
+	return fieldMap
+}
+
+func serverInfoToYAML(server *ServerInfo) map[string]any {
+	yamlServer := map[string]any{"type": server.Type}
+	if server.Location != "" {
+		yamlServer["location"] = server.Location
+	}
+	if server.Format != "" {
+		yamlServer["format"] = server.Format
+	}
+	if server.Endpoint != "" {
+		yamlServer["endpoint"] = server.Endpoint
+	}
+
+	return yamlServer
+}
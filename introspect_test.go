@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestModelKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "lowercases the first letter", input: "Order", want: "order"},
+		{name: "leaves an already-lowercase name alone", input: "order", want: "order"},
+		{name: "empty name is rejected", input: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := modelKey(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for an empty name")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("modelKey: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("modelKey(%q) = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestModelFieldToYAMLNestedShape(t *testing.T) {
+	field := ModelField{
+		Name:     "tags",
+		Required: true,
+		Type:     "array",
+		Items: &ModelField{
+			Type:   "object",
+			Fields: []ModelField{{Name: "key", Type: "string"}},
+		},
+	}
+
+	yamlField := modelFieldToYAML(field)
+
+	if yamlField["type"] != "array" {
+		t.Fatalf("type = %v, want array", yamlField["type"])
+	}
+	if yamlField["required"] != true {
+		t.Fatalf("required = %v, want true", yamlField["required"])
+	}
+
+	items, ok := yamlField["items"].(map[string]any)
+	if !ok || items["type"] != "object" {
+		t.Fatalf("expected an object items map, got %+v", yamlField["items"])
+	}
+
+	nested, ok := items["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected items.fields, got %+v", items)
+	}
+	key, ok := nested["key"].(map[string]any)
+	if !ok || key["type"] != "string" {
+		t.Fatalf("expected items.fields.key to be a string field, got %+v", nested["key"])
+	}
+}
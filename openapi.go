@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type openAPIDocument struct {
+	Components struct {
+		Schemas map[string]openAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+	Definitions map[string]openAPISchema `yaml:"definitions"`
+}
+
+type openAPISchema struct {
+	Ref         string                   `yaml:"$ref"`
+	Type        string                   `yaml:"type"`
+	Format      string                   `yaml:"format"`
+	Description string                   `yaml:"description"`
+	Properties  map[string]openAPISchema `yaml:"properties"`
+	Items       *openAPISchema           `yaml:"items"`
+	Required    []string                 `yaml:"required"`
+	Enum        []string                 `yaml:"enum"`
+	AllOf       []openAPISchema          `yaml:"allOf"`
+}
+
+// modelsFromOpenAPI parses an OpenAPI 3 or Swagger 2 document and translates
+// the schema referenced by schemaRef (e.g. "#/components/schemas/Order")
+// into a datacontract "models" map.
+func modelsFromOpenAPI(specPath, schemaRef string) (map[string]any, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	name, resolved, err := resolveOpenAPIRef(doc, schemaRef)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := modelKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"models": map[string]any{
+			key: map[string]any{
+				"type":   "table",
+				"fields": openAPIFields(doc, resolved, nil),
+			},
+		},
+	}, nil
+}
+
+func modelKey(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("cannot derive a model key from an empty name")
+	}
+
+	return strings.ToLower(name[:1]) + name[1:], nil
+}
+
+func resolveOpenAPIRef(doc openAPIDocument, ref string) (string, openAPISchema, error) {
+	parts := strings.Split(ref, "/")
+	name := parts[len(parts)-1]
+
+	if schema, ok := doc.Components.Schemas[name]; ok {
+		return name, schema, nil
+	}
+	if schema, ok := doc.Definitions[name]; ok {
+		return name, schema, nil
+	}
+
+	return "", openAPISchema{}, fmt.Errorf("schema ref %v not found", ref)
+}
+
+// dereference follows a single $ref, falling back to schema itself if it
+// isn't a reference or can't be resolved.
+func dereference(doc openAPIDocument, schema openAPISchema) openAPISchema {
+	if schema.Ref == "" {
+		return schema
+	}
+
+	_, resolved, err := resolveOpenAPIRef(doc, schema.Ref)
+	if err != nil {
+		return schema
+	}
+
+	return resolved
+}
+
+// withRef returns ancestors extended with ref, leaving ancestors itself
+// untouched, or ancestors unchanged if ref is empty (schema isn't a $ref).
+func withRef(ancestors map[string]bool, ref string) map[string]bool {
+	if ref == "" {
+		return ancestors
+	}
+
+	nested := make(map[string]bool, len(ancestors)+1)
+	for seen := range ancestors {
+		nested[seen] = true
+	}
+	nested[ref] = true
+
+	return nested
+}
+
+// mergeAllOf flattens allOf composition into a single schema with the union
+// of all branches' properties and required fields, keeping the composing
+// schema's own description/format. A schema whose type is only implied by
+// its allOf branches (the common `{allOf: [{$ref: ...}], description: ...}`
+// shape) is inferred as "object" once it has merged properties.
+//
+// ancestors tracks the $refs already being expanded on the path to schema,
+// so a self- or mutually-referential $ref cycle (trees, threaded comments,
+// org charts) stops instead of recursing forever, whether the cycle is
+// reached through a direct $ref or through an allOf branch. mergeAllOf
+// returns the ancestor set extended with every $ref it consumed while
+// flattening, so the caller can keep recursing without losing track of
+// refs that were only visible inside an allOf branch, plus the ref that
+// closed a cycle (empty if schema didn't cycle back to an ancestor).
+func mergeAllOf(doc openAPIDocument, schema openAPISchema, ancestors map[string]bool) (openAPISchema, map[string]bool, string) {
+	if schema.Ref != "" && ancestors[schema.Ref] {
+		return schema, ancestors, schema.Ref
+	}
+
+	ancestors = withRef(ancestors, schema.Ref)
+	schema = dereference(doc, schema)
+	if len(schema.AllOf) == 0 {
+		return schema, ancestors, ""
+	}
+
+	merged := schema
+	merged.AllOf = nil
+	merged.Properties = make(map[string]openAPISchema)
+
+	var cycleRef string
+	for _, part := range schema.AllOf {
+		resolved, nestedAncestors, ref := mergeAllOf(doc, part, ancestors)
+		ancestors = nestedAncestors
+		if ref != "" {
+			cycleRef = ref
+			continue
+		}
+
+		for name, prop := range resolved.Properties {
+			merged.Properties[name] = prop
+		}
+		merged.Required = append(merged.Required, resolved.Required...)
+
+		if merged.Type == "" {
+			merged.Type = resolved.Type
+		}
+	}
+
+	if merged.Type == "" && len(merged.Properties) > 0 {
+		merged.Type = "object"
+	}
+
+	return merged, ancestors, cycleRef
+}
+
+func openAPIFields(doc openAPIDocument, schema openAPISchema, ancestors map[string]bool) map[string]any {
+	merged, nested, cycleRef := mergeAllOf(doc, schema, ancestors)
+	if cycleRef != "" {
+		return map[string]any{}
+	}
+
+	required := make(map[string]bool, len(merged.Required))
+	for _, name := range merged.Required {
+		required[name] = true
+	}
+
+	fields := make(map[string]any, len(merged.Properties))
+	for name, propSchema := range merged.Properties {
+		fields[name] = openAPIField(doc, propSchema, required[name], nested)
+	}
+
+	return fields
+}
+
+func openAPIField(doc openAPIDocument, schema openAPISchema, required bool, ancestors map[string]bool) map[string]any {
+	if ref := schema.Ref; ref != "" && ancestors[ref] {
+		// Cycle back to a schema already being expanded: stop and emit a
+		// reference to it by name instead of recursing forever.
+		return map[string]any{"type": "object", "$ref": ref}
+	}
+
+	merged, nested, cycleRef := mergeAllOf(doc, schema, ancestors)
+	if cycleRef != "" {
+		// The cycle was reached through an allOf branch (e.g. `{allOf:
+		// [{$ref: ...}]}`) rather than schema's own $ref: stop here too.
+		return map[string]any{"type": "object", "$ref": cycleRef}
+	}
+	schema = merged
+
+	field := map[string]any{
+		"type": openAPIFieldType(schema),
+	}
+
+	if schema.Description != "" {
+		field["description"] = schema.Description
+	}
+	if required {
+		field["required"] = true
+	}
+	if len(schema.Enum) > 0 {
+		field["enum"] = schema.Enum
+	}
+
+	switch schema.Type {
+	case "object":
+		field["fields"] = openAPIFields(doc, schema, nested)
+	case "array":
+		if schema.Items != nil {
+			field["items"] = openAPIField(doc, *schema.Items, false, nested)
+		}
+	}
+
+	return field
+}
+
+func openAPIFieldType(schema openAPISchema) string {
+	switch schema.Format {
+	case "date":
+		return "date"
+	case "date-time":
+		return "timestamp"
+	case "uuid", "email":
+		return "string"
+	}
+
+	switch schema.Type {
+	case "integer":
+		return "integer"
+	case "number":
+		return "double"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return "array"
+	case "object":
+		return "object"
+	default:
+		return "string"
+	}
+}
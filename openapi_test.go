@@ -0,0 +1,156 @@
+package main
+
+import "testing"
+
+func TestOpenAPIFieldResolvesAllOfOnPropertySchema(t *testing.T) {
+	doc := openAPIDocument{}
+	doc.Components.Schemas = map[string]openAPISchema{
+		"Order": {
+			Type: "object",
+			Properties: map[string]openAPISchema{
+				"customer": {
+					Description: "the customer who placed the order",
+					AllOf: []openAPISchema{
+						{Ref: "#/components/schemas/Customer"},
+					},
+				},
+			},
+		},
+		"Customer": {
+			Type: "object",
+			Properties: map[string]openAPISchema{
+				"name": {Type: "string"},
+			},
+		},
+	}
+
+	fields := openAPIFields(doc, doc.Components.Schemas["Order"], nil)
+
+	customer, ok := fields["customer"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a customer field, got %+v", fields)
+	}
+	if customer["type"] != "object" {
+		t.Fatalf("customer.type = %v, want object", customer["type"])
+	}
+	if customer["description"] != "the customer who placed the order" {
+		t.Fatalf("customer.description = %v, want the composing schema's own description", customer["description"])
+	}
+
+	nested, ok := customer["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected customer.fields to be resolved through allOf, got %+v", customer)
+	}
+	if _, ok := nested["name"]; !ok {
+		t.Fatalf("expected customer.fields.name from the allOf $ref branch, got %+v", nested)
+	}
+}
+
+func TestOpenAPIFieldStopsOnCyclicRef(t *testing.T) {
+	doc := openAPIDocument{}
+	doc.Components.Schemas = map[string]openAPISchema{
+		"Comment": {
+			Type: "object",
+			Properties: map[string]openAPISchema{
+				"body": {Type: "string"},
+				"replies": {
+					Type:  "array",
+					Items: &openAPISchema{Ref: "#/components/schemas/Comment"},
+				},
+			},
+		},
+	}
+
+	fields := openAPIFields(doc, doc.Components.Schemas["Comment"], nil)
+
+	// The first level of replies expands fully...
+	replies, ok := fields["replies"].(map[string]any)
+	if !ok || replies["type"] != "array" {
+		t.Fatalf("expected replies to be an array field, got %+v", fields["replies"])
+	}
+	items, ok := replies["items"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected replies.items, got %+v", replies)
+	}
+	nestedFields, ok := items["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected the first level of replies to expand its fields, got %+v", items)
+	}
+
+	// ...but the second level cycles back to Comment, so it stops
+	// expanding and emits a reference instead of recursing forever.
+	grandchildReplies, ok := nestedFields["replies"].(map[string]any)
+	if !ok || grandchildReplies["type"] != "array" {
+		t.Fatalf("expected nested replies to be an array field, got %+v", nestedFields["replies"])
+	}
+	grandchildItems, ok := grandchildReplies["items"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected nested replies.items, got %+v", grandchildReplies)
+	}
+	if grandchildItems["type"] != "object" {
+		t.Fatalf("grandchildItems.type = %v, want object", grandchildItems["type"])
+	}
+	if _, stillExpanded := grandchildItems["fields"]; stillExpanded {
+		t.Fatalf("expected the cycle back to Comment to stop expanding fields, got %+v", grandchildItems)
+	}
+	if grandchildItems["$ref"] != "#/components/schemas/Comment" {
+		t.Fatalf("grandchildItems[$ref] = %v, want #/components/schemas/Comment", grandchildItems["$ref"])
+	}
+}
+
+func TestOpenAPIFieldStopsOnCyclicRefThroughAllOf(t *testing.T) {
+	doc := openAPIDocument{}
+	doc.Components.Schemas = map[string]openAPISchema{
+		"Category": {
+			Type: "object",
+			Properties: map[string]openAPISchema{
+				"name": {Type: "string"},
+				"children": {
+					Type: "array",
+					Items: &openAPISchema{
+						AllOf: []openAPISchema{
+							{Ref: "#/components/schemas/Category"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fields := openAPIFields(doc, doc.Components.Schemas["Category"], nil)
+
+	// The first level of children expands fully...
+	children, ok := fields["children"].(map[string]any)
+	if !ok || children["type"] != "array" {
+		t.Fatalf("expected children to be an array field, got %+v", fields["children"])
+	}
+	items, ok := children["items"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected children.items, got %+v", children)
+	}
+	nestedFields, ok := items["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected the first level of children to expand its fields, got %+v", items)
+	}
+
+	// ...but the second level cycles back to Category through the allOf
+	// branch rather than a direct $ref, so it stops expanding and emits a
+	// reference instead of recursing forever.
+	grandchildren, ok := nestedFields["children"].(map[string]any)
+	if !ok || grandchildren["type"] != "array" {
+		t.Fatalf("expected grandchildren to be an array field, got %+v", nestedFields["children"])
+	}
+	grandchildItems, ok := grandchildren["items"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected grandchildren.items, got %+v", grandchildren)
+	}
+	if grandchildItems["type"] != "object" {
+		t.Fatalf("grandchildItems.type = %v, want object", grandchildItems["type"])
+	}
+	if _, stillExpanded := grandchildItems["fields"]; stillExpanded {
+		t.Fatalf("expected the cycle back to Category to stop expanding fields, got %+v", grandchildItems)
+	}
+	if grandchildItems["$ref"] != "#/components/schemas/Category" {
+		t.Fatalf("grandchildItems[$ref] = %v, want #/components/schemas/Category", grandchildItems["$ref"])
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type postgresIntrospector struct{}
+
+// Describe reads information_schema.columns for the table addressed as
+// "postgres://host/db/schema.table", using ambient connection credentials.
+func (postgresIntrospector) Describe(ctx context.Context, uri string) (*Model, *ServerInfo, error) {
+	dsn, schemaName, tableName, err := parsePostgresURI(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close(ctx)
+
+	rows, err := conn.Query(ctx, `
+		select column_name, data_type, is_nullable
+		from information_schema.columns
+		where table_schema = $1 and table_name = $2
+		order by ordinal_position`, schemaName, tableName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var fields []ModelField
+	for rows.Next() {
+		var name, dataType, nullable string
+		if err := rows.Scan(&name, &dataType, &nullable); err != nil {
+			return nil, nil, err
+		}
+
+		fields = append(fields, ModelField{
+			Name:     name,
+			Type:     postgresFieldType(dataType),
+			Required: nullable == "NO",
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	model := &Model{Name: tableName, Fields: fields}
+	server := &ServerInfo{Type: "postgres", Format: "postgres"}
+
+	return model, server, nil
+}
+
+func postgresFieldType(dataType string) string {
+	switch dataType {
+	case "integer", "bigint", "smallint":
+		return "integer"
+	case "double precision", "real", "numeric":
+		return "double"
+	case "boolean":
+		return "boolean"
+	case "timestamp without time zone", "timestamp with time zone":
+		return "timestamp"
+	case "date":
+		return "date"
+	case "bytea":
+		return "binary"
+	default:
+		return "string"
+	}
+}
+
+// parsePostgresURI splits "postgres://host/db/schema.table" into a
+// connection string and the schema/table to describe.
+func parsePostgresURI(uri string) (dsn, schemaName, tableName string, err error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	pathParts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(pathParts) != 2 {
+		return "", "", "", fmt.Errorf("invalid postgres uri %q, expected postgres://host/db/schema.table", uri)
+	}
+
+	table := strings.SplitN(pathParts[1], ".", 2)
+	if len(table) != 2 {
+		return "", "", "", fmt.Errorf("invalid postgres uri %q, expected schema.table", uri)
+	}
+
+	parsed.Path = "/" + pathParts[0]
+
+	return parsed.String(), table[0], table[1], nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func TestParsePostgresURI(t *testing.T) {
+	tests := []struct {
+		name       string
+		uri        string
+		wantDSN    string
+		wantSchema string
+		wantTable  string
+		wantErr    bool
+	}{
+		{
+			name:       "valid uri",
+			uri:        "postgres://localhost:5432/mydb/public.orders",
+			wantDSN:    "postgres://localhost:5432/mydb",
+			wantSchema: "public",
+			wantTable:  "orders",
+		},
+		{
+			name:    "missing schema.table segment",
+			uri:     "postgres://localhost:5432/mydb",
+			wantErr: true,
+		},
+		{
+			name:    "missing dot between schema and table",
+			uri:     "postgres://localhost:5432/mydb/orders",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dsn, schemaName, tableName, err := parsePostgresURI(test.uri)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", test.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePostgresURI: %v", err)
+			}
+			if dsn != test.wantDSN || schemaName != test.wantSchema || tableName != test.wantTable {
+				t.Fatalf("got (%q, %q, %q), want (%q, %q, %q)",
+					dsn, schemaName, tableName, test.wantDSN, test.wantSchema, test.wantTable)
+			}
+		})
+	}
+}
+
+func TestPostgresFieldType(t *testing.T) {
+	tests := []struct {
+		dataType string
+		want     string
+	}{
+		{"integer", "integer"},
+		{"bigint", "integer"},
+		{"smallint", "integer"},
+		{"double precision", "double"},
+		{"real", "double"},
+		{"numeric", "double"},
+		{"boolean", "boolean"},
+		{"timestamp without time zone", "timestamp"},
+		{"timestamp with time zone", "timestamp"},
+		{"date", "date"},
+		{"bytea", "binary"},
+		{"jsonb", "string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dataType, func(t *testing.T) {
+			if got := postgresFieldType(test.dataType); got != test.want {
+				t.Fatalf("postgresFieldType(%v) = %v, want %v", test.dataType, got, test.want)
+			}
+		})
+	}
+}
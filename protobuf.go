@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// bqFieldExtensionNumber is the field number of this org's `bq_field`
+// custom FieldOptions extension used to annotate classification/PII.
+const bqFieldExtensionNumber = 1143
+
+// modelsFromProto parses protoFile and translates the selected message
+// (e.g. "pkg.Order") into a datacontract "models" map.
+func modelsFromProto(protoFile, message string) (map[string]any, error) {
+	parser := protoparse.Parser{
+		ImportPaths:           []string{filepath.Dir(protoFile)},
+		IncludeSourceCodeInfo: true,
+	}
+
+	fileDescriptors, err := parser.ParseFiles(filepath.Base(protoFile))
+	if err != nil {
+		return nil, err
+	}
+
+	messageDescriptor := findMessage(fileDescriptors, message)
+	if messageDescriptor == nil {
+		return nil, fmt.Errorf("message %v not found in %v", message, protoFile)
+	}
+
+	key, err := modelKey(messageDescriptor.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"models": map[string]any{
+			key: map[string]any{
+				"type":   "table",
+				"fields": protoFields(messageDescriptor, nil),
+			},
+		},
+	}, nil
+}
+
+func findMessage(fileDescriptors []*desc.FileDescriptor, message string) *desc.MessageDescriptor {
+	for _, file := range fileDescriptors {
+		for _, candidate := range file.GetMessageTypes() {
+			if found := findMessageIn(candidate, message); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func findMessageIn(candidate *desc.MessageDescriptor, message string) *desc.MessageDescriptor {
+	if candidate.GetFullyQualifiedName() == message || candidate.GetName() == message {
+		return candidate
+	}
+	for _, nested := range candidate.GetNestedMessageTypes() {
+		if found := findMessageIn(nested, message); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// protoFields walks message's fields, tracking the chain of enclosing
+// message types in ancestors so a self- or mutually-referential message
+// graph (e.g. a Category with repeated child Categories) terminates
+// instead of recursing forever.
+func protoFields(message *desc.MessageDescriptor, ancestors map[string]bool) map[string]any {
+	fields := make(map[string]any, len(message.GetFields()))
+
+	for _, field := range message.GetFields() {
+		fields[field.GetName()] = protoField(field, ancestors)
+	}
+
+	return fields
+}
+
+func protoField(field *desc.FieldDescriptor, ancestors map[string]bool) map[string]any {
+	var fieldMap map[string]any
+	if field.IsRepeated() {
+		fieldMap = map[string]any{
+			"type":  "array",
+			"items": protoScalarOrMessageField(field, ancestors),
+		}
+	} else {
+		fieldMap = protoScalarOrMessageField(field, ancestors)
+	}
+
+	if comment := strings.TrimSpace(field.GetSourceInfo().GetLeadingComments()); comment != "" {
+		fieldMap["description"] = comment
+	}
+
+	if tag := bqFieldTag(field); tag != "" {
+		fieldMap["classification"] = tag
+	}
+
+	return fieldMap
+}
+
+func protoScalarOrMessageField(field *desc.FieldDescriptor, ancestors map[string]bool) map[string]any {
+	if messageType := field.GetMessageType(); messageType != nil {
+		name := messageType.GetFullyQualifiedName()
+		if name == "google.protobuf.Timestamp" {
+			return map[string]any{"type": "timestamp"}
+		}
+		if ancestors[name] {
+			// Cycle back to an ancestor message: stop expanding and emit a
+			// reference to it by name instead of recursing forever.
+			return map[string]any{"type": "object", "$ref": name}
+		}
+
+		nested := make(map[string]bool, len(ancestors)+1)
+		for ancestor := range ancestors {
+			nested[ancestor] = true
+		}
+		nested[name] = true
+
+		return map[string]any{"type": "object", "fields": protoFields(messageType, nested)}
+	}
+
+	if enumType := field.GetEnumType(); enumType != nil {
+		return map[string]any{"type": "string", "enum": protoEnumValues(enumType)}
+	}
+
+	return map[string]any{"type": protoScalarType(field)}
+}
+
+func protoEnumValues(enum *desc.EnumDescriptor) []string {
+	values := make([]string, 0, len(enum.GetValues()))
+	for _, value := range enum.GetValues() {
+		values = append(values, value.GetName())
+	}
+	return values
+}
+
+func protoScalarType(field *desc.FieldDescriptor) string {
+	switch field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32, descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return "integer"
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return "double"
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "boolean"
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return "binary"
+	default:
+		return "string"
+	}
+}
+
+func bqFieldTag(field *desc.FieldDescriptor) string {
+	options := field.AsFieldDescriptorProto().GetOptions()
+	if options == nil {
+		return ""
+	}
+
+	value, ok := decodeStringExtension(options.ProtoReflect().GetUnknown(), bqFieldExtensionNumber)
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// decodeStringExtension scans raw unrecognized protobuf wire bytes for a
+// length-delimited field with the given number, since the bq_field
+// extension isn't registered with the parser's descriptor.
+func decodeStringExtension(raw []byte, fieldNumber int32) (string, bool) {
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return "", false
+		}
+		raw = raw[n:]
+
+		if typ != protowire.BytesType {
+			n := protowire.ConsumeFieldValue(num, typ, raw)
+			if n < 0 {
+				return "", false
+			}
+			raw = raw[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(raw)
+		if n < 0 {
+			return "", false
+		}
+		raw = raw[n:]
+
+		if int32(num) == fieldNumber {
+			return string(value), true
+		}
+	}
+
+	return "", false
+}
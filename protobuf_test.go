@@ -0,0 +1,154 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestModelsFromProtoSelfReferentialMessageDoesNotRecurseForever(t *testing.T) {
+	models, err := modelsFromProto("testdata/category.proto", "Category")
+	if err != nil {
+		t.Fatalf("modelsFromProto: %v", err)
+	}
+
+	category, ok := models["models"].(map[string]any)["category"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a category model, got %+v", models)
+	}
+
+	fields, ok := category["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected category fields, got %+v", category)
+	}
+
+	// The first level of children expands fully...
+	children, ok := fields["children"].(map[string]any)
+	if !ok || children["type"] != "array" {
+		t.Fatalf("expected children to be an array field, got %+v", fields["children"])
+	}
+	items, ok := children["items"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected children.items, got %+v", children)
+	}
+	nestedFields, ok := items["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected the first level of children to expand its fields, got %+v", items)
+	}
+
+	// ...but the second level cycles back to Category, so it stops
+	// expanding and emits a reference instead of recursing forever.
+	grandchildren, ok := nestedFields["children"].(map[string]any)
+	if !ok || grandchildren["type"] != "array" {
+		t.Fatalf("expected grandchildren to be an array field, got %+v", nestedFields["children"])
+	}
+	grandchildItems, ok := grandchildren["items"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected grandchildren.items, got %+v", grandchildren)
+	}
+	if grandchildItems["type"] != "object" {
+		t.Fatalf("grandchildItems.type = %v, want object", grandchildItems["type"])
+	}
+	if _, stillExpanded := grandchildItems["fields"]; stillExpanded {
+		t.Fatalf("expected the cycle back to Category to stop expanding fields, got %+v", grandchildItems)
+	}
+	if grandchildItems["$ref"] != "catalog.Category" {
+		t.Fatalf("grandchildItems[$ref] = %v, want catalog.Category", grandchildItems["$ref"])
+	}
+}
+
+func TestModelsFromProtoAttachesLeadingCommentsAsDescriptions(t *testing.T) {
+	models, err := modelsFromProto("testdata/commented.proto", "Order")
+	if err != nil {
+		t.Fatalf("modelsFromProto: %v", err)
+	}
+
+	fields, ok := models["models"].(map[string]any)["order"].(map[string]any)["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected order fields, got %+v", models)
+	}
+
+	id, ok := fields["id"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected an id field, got %+v", fields)
+	}
+	if id["description"] != "The customer-facing order identifier." {
+		t.Fatalf("id[description] = %v, want the leading comment", id["description"])
+	}
+
+	quantity, ok := fields["quantity"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a quantity field, got %+v", fields)
+	}
+	if _, hasDescription := quantity["description"]; hasDescription {
+		t.Fatalf("expected quantity to have no description, got %+v", quantity)
+	}
+}
+
+func appendStringField(raw []byte, number int32, value string) []byte {
+	raw = protowire.AppendTag(raw, protowire.Number(number), protowire.BytesType)
+	return protowire.AppendString(raw, value)
+}
+
+func appendVarintField(raw []byte, number int32, value uint64) []byte {
+	raw = protowire.AppendTag(raw, protowire.Number(number), protowire.VarintType)
+	return protowire.AppendVarint(raw, value)
+}
+
+func TestDecodeStringExtension(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       []byte
+		wantValue string
+		wantFound bool
+	}{
+		{
+			name:      "empty input",
+			raw:       nil,
+			wantValue: "",
+			wantFound: false,
+		},
+		{
+			name:      "target field alone",
+			raw:       appendStringField(nil, bqFieldExtensionNumber, "pii"),
+			wantValue: "pii",
+			wantFound: true,
+		},
+		{
+			name: "target field before an unrelated varint field",
+			raw: appendVarintField(
+				appendStringField(nil, bqFieldExtensionNumber, "pii"),
+				99, 7,
+			),
+			wantValue: "pii",
+			wantFound: true,
+		},
+		{
+			name:      "only unrelated fields",
+			raw:       appendVarintField(nil, 99, 7),
+			wantValue: "",
+			wantFound: false,
+		},
+		{
+			name: "unrelated field precedes the target field",
+			raw: appendStringField(
+				appendVarintField(nil, 99, 7),
+				bqFieldExtensionNumber, "classified",
+			),
+			wantValue: "classified",
+			wantFound: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, found := decodeStringExtension(test.raw, bqFieldExtensionNumber)
+			if found != test.wantFound {
+				t.Fatalf("found = %v, want %v", found, test.wantFound)
+			}
+			if value != test.wantValue {
+				t.Fatalf("value = %q, want %q", value, test.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/segmentio/parquet-go"
+)
+
+type s3Introspector struct{}
+
+// Describe discovers the schema of objects under an
+// "s3://bucket/prefix?format=parquet|csv" URI using ambient AWS SDK
+// credentials, by reading the first matching object (parquet is the
+// default format, matching a bare "s3://bucket/prefix"). Other formats
+// return an error rather than silently bootstrapping a model with no
+// fields.
+func (s3Introspector) Describe(ctx context.Context, uri string) (*Model, *ServerInfo, error) {
+	bucket, prefix, format, err := parseS3URI(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name, err := modelNameFromPrefix(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client := s3.NewFromConfig(cfg)
+
+	key, err := firstObjectKey(ctx, client, bucket, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fields []ModelField
+	switch format {
+	case "parquet":
+		fields, err = parquetSchemaFields(ctx, client, bucket, key)
+	case "csv":
+		fields, err = csvHeaderFields(ctx, client, bucket, key)
+	default:
+		err = fmt.Errorf("s3 schema discovery is only implemented for format=parquet or format=csv, got %q", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	model := &Model{Name: name, Fields: fields}
+
+	server := &ServerInfo{
+		Type:     "s3",
+		Format:   format,
+		Endpoint: fmt.Sprintf("s3://%v/%v", bucket, prefix),
+	}
+
+	return model, server, nil
+}
+
+func firstObjectKey(ctx context.Context, client *s3.Client, bucket, prefix string) (string, error) {
+	output, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(1),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(output.Contents) == 0 {
+		return "", fmt.Errorf("no objects found under s3://%v/%v", bucket, prefix)
+	}
+
+	return *output.Contents[0].Key, nil
+}
+
+func getObjectBytes(ctx context.Context, client *s3.Client, bucket, key string) ([]byte, error) {
+	object, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	return io.ReadAll(object.Body)
+}
+
+func csvHeaderFields(ctx context.Context, client *s3.Client, bucket, key string) ([]ModelField, error) {
+	data, err := getObjectBytes(ctx, client, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := csv.NewReader(bufio.NewReader(bytes.NewReader(data))).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]ModelField, 0, len(header))
+	for _, column := range header {
+		fields = append(fields, ModelField{Name: column, Type: "string"})
+	}
+
+	return fields, nil
+}
+
+// parquetSchemaFields reads the footer of the first matching object (only
+// the parquet magic bytes and footer are read, not the row data) and
+// translates its schema into ModelFields. It discovers the object's size
+// with a HEAD request, then lets parquet.OpenFile pull the footer through
+// s3ReaderAt via ranged GetObject requests, so a GB-scale file never gets
+// downloaded in full just to read its schema.
+func parquetSchemaFields(ctx context.Context, client *s3.Client, bucket, key string) ([]ModelField, error) {
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, err
+	}
+	if head.ContentLength == nil {
+		return nil, fmt.Errorf("s3://%v/%v: missing Content-Length", bucket, key)
+	}
+
+	reader := &s3ReaderAt{ctx: ctx, client: client, bucket: bucket, key: key}
+
+	file, err := parquet.OpenFile(reader, *head.ContentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return parquetFields(file.Schema().Fields()), nil
+}
+
+// s3ReaderAt adapts ranged S3 GetObject requests to io.ReaderAt, so
+// parquet.OpenFile can seek straight to the footer instead of requiring
+// the whole object in memory.
+type s3ReaderAt struct {
+	ctx    context.Context
+	client *s3.Client
+	bucket string
+	key    string
+}
+
+func (r *s3ReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	byteRange := fmt.Sprintf("bytes=%v-%v", off, off+int64(len(p))-1)
+
+	object, err := r.client.GetObject(r.ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(r.key),
+		Range:  aws.String(byteRange),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer object.Body.Close()
+
+	return io.ReadFull(object.Body, p)
+}
+
+func parquetFields(fields []parquet.Field) []ModelField {
+	modelFields := make([]ModelField, 0, len(fields))
+
+	for _, field := range fields {
+		modelFields = append(modelFields, parquetField(field))
+	}
+
+	return modelFields
+}
+
+func parquetField(field parquet.Field) ModelField {
+	modelField := ModelField{
+		Name:     field.Name(),
+		Required: field.Required(),
+	}
+
+	item := parquetItemField(field)
+
+	if field.Repeated() {
+		modelField.Type = "array"
+		modelField.Items = &item
+		return modelField
+	}
+
+	modelField.Type = item.Type
+	modelField.Fields = item.Fields
+
+	return modelField
+}
+
+// parquetItemField resolves field's element type, ignoring Repeated, so it
+// can be used both for a scalar/group column and as an array's item type.
+func parquetItemField(field parquet.Node) ModelField {
+	if !field.Leaf() {
+		return ModelField{Type: "object", Fields: parquetFields(field.Fields())}
+	}
+
+	return ModelField{Type: parquetScalarType(field.Type().Kind())}
+}
+
+func parquetScalarType(kind parquet.Kind) string {
+	switch kind {
+	case parquet.Boolean:
+		return "boolean"
+	case parquet.Int32, parquet.Int64, parquet.Int96:
+		return "integer"
+	case parquet.Float, parquet.Double:
+		return "double"
+	default:
+		return "string"
+	}
+}
+
+func parseS3URI(uri string) (bucket, prefix, format string, err error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if parsed.Scheme != "s3" {
+		return "", "", "", fmt.Errorf("invalid s3 uri %q", uri)
+	}
+	if parsed.Host == "" {
+		return "", "", "", fmt.Errorf("invalid s3 uri %q: missing bucket", uri)
+	}
+
+	format = parsed.Query().Get("format")
+	if format == "" {
+		format = "parquet"
+	}
+
+	return parsed.Host, strings.TrimPrefix(parsed.Path, "/"), format, nil
+}
+
+// modelNameFromPrefix derives a model name from the last path segment of
+// prefix, erroring rather than returning "" for an empty or bare-slash prefix.
+func modelNameFromPrefix(prefix string) (string, error) {
+	trimmed := strings.TrimSuffix(prefix, "/")
+	if trimmed == "" {
+		return "", fmt.Errorf("cannot derive a model name from an empty s3 prefix")
+	}
+
+	parts := strings.Split(trimmed, "/")
+
+	return parts[len(parts)-1], nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func TestParseS3URI(t *testing.T) {
+	tests := []struct {
+		name       string
+		uri        string
+		wantBucket string
+		wantPrefix string
+		wantFormat string
+		wantErr    bool
+	}{
+		{
+			name:       "explicit format",
+			uri:        "s3://my-bucket/orders?format=csv",
+			wantBucket: "my-bucket",
+			wantPrefix: "orders",
+			wantFormat: "csv",
+		},
+		{
+			name:       "format defaults to parquet",
+			uri:        "s3://my-bucket/orders",
+			wantBucket: "my-bucket",
+			wantPrefix: "orders",
+			wantFormat: "parquet",
+		},
+		{
+			name:    "wrong scheme",
+			uri:     "gs://my-bucket/orders",
+			wantErr: true,
+		},
+		{
+			name:    "missing bucket",
+			uri:     "s3:///orders",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bucket, prefix, format, err := parseS3URI(test.uri)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", test.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseS3URI: %v", err)
+			}
+			if bucket != test.wantBucket || prefix != test.wantPrefix || format != test.wantFormat {
+				t.Fatalf("got (%q, %q, %q), want (%q, %q, %q)",
+					bucket, prefix, format, test.wantBucket, test.wantPrefix, test.wantFormat)
+			}
+		})
+	}
+}
+
+func TestModelNameFromPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		want    string
+		wantErr bool
+	}{
+		{name: "simple prefix", prefix: "orders", want: "orders"},
+		{name: "nested prefix", prefix: "raw/orders/2024", want: "2024"},
+		{name: "trailing slash", prefix: "raw/orders/", want: "orders"},
+		{name: "empty prefix", prefix: "", wantErr: true},
+		{name: "bare slash", prefix: "/", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := modelNameFromPrefix(test.prefix)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for prefix %q", test.prefix)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("modelNameFromPrefix: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("modelNameFromPrefix(%q) = %q, want %q", test.prefix, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParquetScalarType(t *testing.T) {
+	tests := []struct {
+		kind parquet.Kind
+		want string
+	}{
+		{parquet.Boolean, "boolean"},
+		{parquet.Int32, "integer"},
+		{parquet.Int64, "integer"},
+		{parquet.Int96, "integer"},
+		{parquet.Float, "double"},
+		{parquet.Double, "double"},
+		{parquet.ByteArray, "string"},
+		{parquet.FixedLenByteArray, "string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.kind.String(), func(t *testing.T) {
+			if got := parquetScalarType(test.kind); got != test.want {
+				t.Fatalf("parquetScalarType(%v) = %v, want %v", test.kind, got, test.want)
+			}
+		})
+	}
+}
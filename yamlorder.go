@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// orderedField is one key/value pair of an orderedMap, in the order it was
+// appended.
+type orderedField struct {
+	Key   string
+	Value any
+}
+
+// orderedMap preserves field order when marshaled to YAML or JSON, so a
+// generated datacontract follows the schema's own field order
+// (dataContractSpecification, id, info, models, ...) instead of the
+// alphabetical order yaml.v3 and encoding/json impose on a plain map.
+type orderedMap []orderedField
+
+// set overwrites the value for key if present, preserving its position, or
+// appends a new field otherwise.
+func (om orderedMap) set(key string, value any) orderedMap {
+	for i, field := range om {
+		if field.Key == key {
+			om[i].Value = value
+			return om
+		}
+	}
+
+	return append(om, orderedField{key, value})
+}
+
+func (om orderedMap) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, field := range om {
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(field.Value); err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field.Key}, valueNode)
+	}
+
+	return node, nil
+}
+
+func (om orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for i, field := range om {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
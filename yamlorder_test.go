@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestOrderedMapMarshalYAMLPreservesOrder(t *testing.T) {
+	om := orderedMap{
+		{"dataContractSpecification", "1.1.0"},
+		{"id", "order-events"},
+		{"info", orderedMap{
+			{"title", "Order Events"},
+			{"owner", "team-x"},
+		}},
+	}
+
+	data, err := yaml.Marshal(om)
+	if err != nil {
+		t.Fatalf("yaml.Marshal: %v", err)
+	}
+
+	wantOrder := []string{"dataContractSpecification:", "id:", "info:", "title:", "owner:"}
+	lastIndex := -1
+	for _, key := range wantOrder {
+		index := strings.Index(string(data), key)
+		if index == -1 {
+			t.Fatalf("expected %q in output:\n%s", key, data)
+		}
+		if index < lastIndex {
+			t.Fatalf("key %q appeared out of order in output:\n%s", key, data)
+		}
+		lastIndex = index
+	}
+}
+
+func TestOrderedMapMarshalJSONPreservesOrder(t *testing.T) {
+	om := orderedMap{
+		{"dataContractSpecification", "1.1.0"},
+		{"id", "order-events"},
+		{"info", orderedMap{
+			{"title", "Order Events"},
+			{"owner", "team-x"},
+		}},
+	}
+
+	data, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	want := `{"dataContractSpecification":"1.1.0","id":"order-events","info":{"title":"Order Events","owner":"team-x"}}`
+	if string(data) != want {
+		t.Fatalf("got %s, want %s", data, want)
+	}
+
+	var roundTripped map[string]any
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if roundTripped["id"] != "order-events" {
+		t.Fatalf("round-tripped id = %v, want order-events", roundTripped["id"])
+	}
+}
+
+func TestOrderedMapSet(t *testing.T) {
+	om := orderedMap{
+		{"dataContractSpecification", "1.1.0"},
+		{"models", []any{}},
+	}
+
+	om = om.set("models", orderedMap{{"order", "..."}})
+	if len(om) != 2 {
+		t.Fatalf("set on an existing key should not append, got %d fields", len(om))
+	}
+	if om[1].Key != "models" {
+		t.Fatalf("set on an existing key should preserve position, got key %q at index 1", om[1].Key)
+	}
+
+	om = om.set("servers", orderedMap{{"production", "..."}})
+	if len(om) != 3 || om[2].Key != "servers" {
+		t.Fatalf("set on a new key should append, got %+v", om)
+	}
+}